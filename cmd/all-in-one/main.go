@@ -10,48 +10,64 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/app"
 	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/config"
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/lifecycle"
 	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/telemetry"
-	"go.opentelemetry.io/contrib/bridges/otelzap"
-	"go.opentelemetry.io/otel"
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/zpages"
 	"go.opentelemetry.io/otel/codes"
-	"go.opentelemetry.io/otel/log/global"
 	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 )
 
+// serviceRoutes lists the HTTP routes each built-in service exposes, for
+// the /debug/servicez page.
+var serviceRoutes = map[string][]string{
+	"user":         {"/users"},
+	"plan":         {"/plans"},
+	"payment":      {"/payments"},
+	"subscription": {"/subscriptions"},
+}
+
 func main() {
 	configFlag := flag.String("config", "", "path to the config file")
 	otelconfigFlag := flag.String("otel", "otel.yaml", "path to the otel config file")
 	flag.Parse()
 
-	closer, err := telemetry.Setup(context.Background(), *otelconfigFlag)
+	tracezProcessor := zpages.NewProcessor()
+	services := zpages.NewRegistry()
+
+	tel, err := telemetry.Setup(context.Background(), *otelconfigFlag, "all-in-one", tracezProcessor)
 	if err != nil {
 		fmt.Printf("Failed to setup telemetry: %v\n", err)
+		return
 	}
-	defer closer(context.Background())
-
-	_, span := otel.Tracer("all-in-one").Start(context.Background(), "main")
 
-	core := zapcore.NewTee(
-		zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(os.Stdout), zapcore.InfoLevel),
-		otelzap.NewCore("all-in-one", otelzap.WithLoggerProvider(global.GetLoggerProvider())),
-	)
-	logger := zap.New(core)
+	logger := tel.Settings.Logger
+	_, span := tel.Settings.Tracer.Start(context.Background(), "main")
 
 	logger.Info("Starting the all-in-one service")
 	span.AddEvent("Starting the all-in-one service")
-	c, _ := config.LoadConfig(*configFlag)
+	c, err := config.LoadConfig(*configFlag)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		logger.Fatal("failed to load the config", zap.Error(err))
 	}
 
+	app.RegisterBuiltins(tel.Settings)
+
+	if len(c.Services) == 0 {
+		err := fmt.Errorf("no services declared; add a top-level `services:` list to %q", *configFlag)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Fatal("refusing to start with zero services configured", zap.Error(err))
+	}
+
 	mux := http.NewServeMux()
+	zpages.RegisterRoutes(mux, tracezProcessor, services)
 
 	// starts the gRPC server
 	lis, err := net.Listen("tcp", c.Server.Endpoint.GRPC)
@@ -61,65 +77,67 @@ func main() {
 		logger.Fatal("failed to listen the config", zap.Error(err))
 	}
 
-	var opts []grpc.ServerOption
-	grpcServer := grpc.NewServer(opts...)
-
-	{
-		logger.Info("Starting the user service")
-		span.AddEvent("Starting the user service")
-		a := app.NewUser(&c.Users)
-		a.RegisterRoutes(mux)
-	}
+	grpcServer := grpc.NewServer(telemetry.GRPCServerOptions()...)
 
-	{
-		logger.Info("Starting the plan service")
-		span.AddEvent("Starting the plan service")
-		a := app.NewPlan(&c.Plans)
-		a.RegisterRoutes(mux, grpcServer)
-	}
+	running := make([]app.Service, 0, len(c.Services))
+	for _, entry := range c.Services {
+		logger.Info("Starting service", zap.String("service", entry.Name))
+		span.AddEvent("Starting service: " + entry.Name)
 
-	{
-		logger.Info("Starting the payment service")
-		span.AddEvent("Starting the payment service")
-		a, err := app.NewPayment(&c.Payments)
+		svc, err := app.New(entry.Name, entry.Settings)
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
-			logger.Fatal("failed to create the payment service", zap.Error(err))
+			logger.Fatal("failed to create service", zap.String("service", entry.Name), zap.Error(err))
+		}
+		if err := svc.RegisterRoutes(mux, grpcServer); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			logger.Fatal("failed to register routes", zap.String("service", entry.Name), zap.Error(err))
 		}
-		a.RegisterRoutes(mux)
-		defer func() {
-			logger.Info("Shutting down the payment service")
-			err = a.Shutdown()
-			if err != nil {
-				span.RecordError(err)
-				span.SetStatus(codes.Error, err.Error())
-				logger.Fatal("Failed to shutdown the payment service", zap.Error(err))
-			}
-		}()
+
+		services.RegisterService(entry.Name, serviceRoutes[entry.Name])
+		running = append(running, svc)
 	}
 
-	{
-		logger.Info("Starting the subscriptions service")
-		span.AddEvent("Starting the subscriptions service")
-		a := app.NewSubscription(&c.Subscriptions)
-		a.RegisterRoutes(mux)
+	httpServer := &http.Server{
+		Addr:    c.Server.Endpoint.HTTP,
+		Handler: telemetry.HTTPHandler(mux, "all-in-one"),
 	}
 
+	serveErrors := make(chan error, 2)
+
 	go func() {
-		err = grpcServer.Serve(lis)
-		if err != nil {
+		if err := grpcServer.Serve(lis); err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
-			logger.Fatal("failed to server", zap.Error(err))
+			serveErrors <- fmt.Errorf("grpc server: %w", err)
+		}
+	}()
+
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			serveErrors <- fmt.Errorf("http server: %w", err)
 		}
 	}()
 
 	span.End()
-	err = http.ListenAndServe(c.Server.Endpoint.HTTP, mux)
-	if err != nil && err != http.ErrServerClosed {
-		logger.Error("failed to serve", zap.Error(err))
+
+	mgr := &lifecycle.Manager{
+		GRPCServer:   grpcServer,
+		HTTPServer:   httpServer,
+		Services:     running,
+		Telemetry:    tel,
+		Logger:       logger,
+		Tracer:       tel.Settings.Tracer,
+		DrainTimeout: 30 * time.Second,
+		ServeErrors:  serveErrors,
+	}
+	if err := mgr.Wait(context.Background()); err != nil {
+		os.Exit(1)
 	}
 
-	logger.Info("Stopping the all-i-one service")
+	logger.Info("Stopping the all-in-one service")
 }
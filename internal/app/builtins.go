@@ -0,0 +1,48 @@
+// Copyright Dose de Telemetria GmbH
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/config"
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/telemetry"
+)
+
+// RegisterBuiltins registers the user, plan, payment and subscription
+// factories under Register, closing over settings so the services built
+// from them use it instead of reaching for global telemetry providers.
+// Callers that boot the all-in-one services, such as cmd/all-in-one and
+// internal/testbed, call this once before building services by name.
+func RegisterBuiltins(settings telemetry.Settings) {
+	Register("user", func(raw any) (Service, error) {
+		var cfg config.UserConfig
+		if err := config.Decode(raw, &cfg); err != nil {
+			return nil, err
+		}
+		return NewUser(&cfg, settings), nil
+	})
+
+	Register("plan", func(raw any) (Service, error) {
+		var cfg config.PlanConfig
+		if err := config.Decode(raw, &cfg); err != nil {
+			return nil, err
+		}
+		return NewPlan(&cfg, settings), nil
+	})
+
+	Register("payment", func(raw any) (Service, error) {
+		var cfg config.PaymentConfig
+		if err := config.Decode(raw, &cfg); err != nil {
+			return nil, err
+		}
+		return NewPayment(&cfg, settings)
+	})
+
+	Register("subscription", func(raw any) (Service, error) {
+		var cfg config.SubscriptionConfig
+		if err := config.Decode(raw, &cfg); err != nil {
+			return nil, err
+		}
+		return NewSubscription(&cfg, settings), nil
+	})
+}
@@ -0,0 +1,47 @@
+// Copyright Dose de Telemetria GmbH
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/config"
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/telemetry"
+	"google.golang.org/grpc"
+)
+
+// Payment serves the payment HTTP routes.
+type Payment struct {
+	cfg      *config.PaymentConfig
+	settings telemetry.Settings
+}
+
+// NewPayment creates a Payment service from cfg. settings supplies the
+// logger and tracer the service uses, instead of reaching for global
+// providers.
+func NewPayment(cfg *config.PaymentConfig, settings telemetry.Settings) (*Payment, error) {
+	return &Payment{cfg: cfg, settings: settings}, nil
+}
+
+// RegisterRoutes mounts the payment HTTP routes on mux. grpcServer is
+// unused; the payment service does not expose a gRPC API.
+func (p *Payment) RegisterRoutes(mux *http.ServeMux, _ *grpc.Server) error {
+	mux.HandleFunc("/payments", p.handlePayments)
+	return nil
+}
+
+func (p *Payment) handlePayments(w http.ResponseWriter, r *http.Request) {
+	_, span := p.settings.Tracer.Start(r.Context(), "Payment.handlePayments")
+	defer span.End()
+
+	p.settings.Logger.Info("handling payments request")
+	w.WriteHeader(http.StatusOK)
+}
+
+// Shutdown releases the resources held by the payment service.
+func (p *Payment) Shutdown(context.Context) error {
+	p.settings.Logger.Info("shutting down the payment service")
+	return nil
+}
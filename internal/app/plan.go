@@ -0,0 +1,45 @@
+// Copyright Dose de Telemetria GmbH
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/config"
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/telemetry"
+	"google.golang.org/grpc"
+)
+
+// Plan serves the plan management HTTP routes.
+type Plan struct {
+	cfg      *config.PlanConfig
+	settings telemetry.Settings
+}
+
+// NewPlan creates a Plan service from cfg. settings supplies the logger and
+// tracer the service uses, instead of reaching for global providers.
+func NewPlan(cfg *config.PlanConfig, settings telemetry.Settings) *Plan {
+	return &Plan{cfg: cfg, settings: settings}
+}
+
+// RegisterRoutes mounts the plan HTTP routes on mux. grpcServer is unused:
+// the plan service has no gRPC API defined yet, so registering it here is
+// a no-op.
+func (p *Plan) RegisterRoutes(mux *http.ServeMux, grpcServer *grpc.Server) error {
+	mux.HandleFunc("/plans", p.handlePlans)
+	return nil
+}
+
+func (p *Plan) handlePlans(w http.ResponseWriter, r *http.Request) {
+	_, span := p.settings.Tracer.Start(r.Context(), "Plan.handlePlans")
+	defer span.End()
+
+	p.settings.Logger.Info("handling plans request")
+	w.WriteHeader(http.StatusOK)
+}
+
+// Shutdown releases the resources held by the plan service. The plan
+// service holds none, so this is a no-op.
+func (p *Plan) Shutdown(context.Context) error { return nil }
@@ -0,0 +1,50 @@
+// Copyright Dose de Telemetria GmbH
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"google.golang.org/grpc"
+)
+
+// Service is implemented by every service the all-in-one binary can host.
+type Service interface {
+	// RegisterRoutes mounts the service's HTTP routes on mux and, for
+	// services that expose one, its gRPC service on grpcServer.
+	RegisterRoutes(mux *http.ServeMux, grpcServer *grpc.Server) error
+	// Shutdown releases the resources held by the service.
+	Shutdown(ctx context.Context) error
+}
+
+// Factory builds a Service from the settings block the config file
+// declared for it.
+type Factory func(cfg any) (Service, error)
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]Factory{}
+)
+
+// Register associates name with factory, so the config file can enable the
+// service by name without cmd/all-in-one/main.go knowing about it.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// New builds the Service registered under name, passing it cfg.
+func New(name string, cfg any) (Service, error) {
+	registryMu.Lock()
+	factory, ok := registry[name]
+	registryMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no service registered under %q", name)
+	}
+	return factory(cfg)
+}
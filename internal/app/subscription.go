@@ -0,0 +1,45 @@
+// Copyright Dose de Telemetria GmbH
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/config"
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/telemetry"
+	"google.golang.org/grpc"
+)
+
+// Subscription serves the subscription management HTTP routes.
+type Subscription struct {
+	cfg      *config.SubscriptionConfig
+	settings telemetry.Settings
+}
+
+// NewSubscription creates a Subscription service from cfg. settings
+// supplies the logger and tracer the service uses, instead of reaching for
+// global providers.
+func NewSubscription(cfg *config.SubscriptionConfig, settings telemetry.Settings) *Subscription {
+	return &Subscription{cfg: cfg, settings: settings}
+}
+
+// RegisterRoutes mounts the subscription HTTP routes on mux. grpcServer is
+// unused; the subscription service does not expose a gRPC API.
+func (s *Subscription) RegisterRoutes(mux *http.ServeMux, _ *grpc.Server) error {
+	mux.HandleFunc("/subscriptions", s.handleSubscriptions)
+	return nil
+}
+
+func (s *Subscription) handleSubscriptions(w http.ResponseWriter, r *http.Request) {
+	_, span := s.settings.Tracer.Start(r.Context(), "Subscription.handleSubscriptions")
+	defer span.End()
+
+	s.settings.Logger.Info("handling subscriptions request")
+	w.WriteHeader(http.StatusOK)
+}
+
+// Shutdown releases the resources held by the subscription service. The
+// subscription service holds none, so this is a no-op.
+func (s *Subscription) Shutdown(context.Context) error { return nil }
@@ -0,0 +1,44 @@
+// Copyright Dose de Telemetria GmbH
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/config"
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/telemetry"
+	"google.golang.org/grpc"
+)
+
+// User serves the user management HTTP routes.
+type User struct {
+	cfg      *config.UserConfig
+	settings telemetry.Settings
+}
+
+// NewUser creates a User service from cfg. settings supplies the logger and
+// tracer the service uses, instead of reaching for global providers.
+func NewUser(cfg *config.UserConfig, settings telemetry.Settings) *User {
+	return &User{cfg: cfg, settings: settings}
+}
+
+// RegisterRoutes mounts the user HTTP routes on mux. grpcServer is unused;
+// the user service does not expose a gRPC API.
+func (u *User) RegisterRoutes(mux *http.ServeMux, _ *grpc.Server) error {
+	mux.HandleFunc("/users", u.handleUsers)
+	return nil
+}
+
+func (u *User) handleUsers(w http.ResponseWriter, r *http.Request) {
+	_, span := u.settings.Tracer.Start(r.Context(), "User.handleUsers")
+	defer span.End()
+
+	u.settings.Logger.Info("handling users request")
+	w.WriteHeader(http.StatusOK)
+}
+
+// Shutdown releases the resources held by the user service. The user
+// service holds none, so this is a no-op.
+func (u *User) Shutdown(context.Context) error { return nil }
@@ -0,0 +1,83 @@
+// Copyright Dose de Telemetria GmbH
+// SPDX-License-Identifier: Apache-2.0
+
+// Package config loads the YAML configuration consumed by the cmd/*
+// binaries.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the root configuration for the all-in-one binary.
+type Config struct {
+	Server ServerConfig `yaml:"server"`
+
+	// Services lists the services to enable, by the name they were
+	// registered under with app.Register, and the settings to build each
+	// one with. This is what lets a deployment run a subset of services
+	// (e.g. only payment and subscription) without code changes.
+	Services []ServiceConfig `yaml:"services"`
+}
+
+// ServiceConfig enables a single service registered with app.Register.
+type ServiceConfig struct {
+	Name     string `yaml:"name"`
+	Settings any    `yaml:"settings"`
+}
+
+// ServerConfig holds the endpoints the all-in-one binary listens on.
+type ServerConfig struct {
+	Endpoint EndpointConfig `yaml:"endpoint"`
+}
+
+// EndpointConfig holds the gRPC and HTTP listen addresses.
+type EndpointConfig struct {
+	GRPC string `yaml:"grpc"`
+	HTTP string `yaml:"http"`
+}
+
+// UserConfig configures the user service.
+type UserConfig struct{}
+
+// PlanConfig configures the plan service.
+type PlanConfig struct{}
+
+// PaymentConfig configures the payment service.
+type PaymentConfig struct{}
+
+// SubscriptionConfig configures the subscription service.
+type SubscriptionConfig struct{}
+
+// LoadConfig reads and parses the YAML configuration file at path.
+func LoadConfig(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the config file: %w", err)
+	}
+
+	var c Config
+	if err := yaml.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse the config file: %w", err)
+	}
+
+	return &c, nil
+}
+
+// Decode re-encodes raw (typically a ServiceConfig.Settings value, decoded
+// by yaml.v3 into generic map[string]any/[]any values) into out, which
+// should be a pointer to a concrete settings struct. Service factories use
+// this to turn their settings block into their own config type.
+func Decode(raw, out any) error {
+	b, err := yaml.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("failed to re-marshal the service settings: %w", err)
+	}
+	if err := yaml.Unmarshal(b, out); err != nil {
+		return fmt.Errorf("failed to decode the service settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright Dose de Telemetria GmbH
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lifecycle coordinates an ordered, traced shutdown of the
+// all-in-one process's components once SIGINT or SIGTERM is received.
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/app"
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/telemetry"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// DefaultDrainTimeout bounds how long Shutdown waits for each component to
+// finish, unless Manager.DrainTimeout overrides it.
+const DefaultDrainTimeout = 30 * time.Second
+
+// Manager tears every component of the all-in-one process down, in order,
+// once asked to shut down: the gRPC server, then the HTTP server, then
+// every service in reverse-registration order, then telemetry.
+type Manager struct {
+	GRPCServer *grpc.Server
+	HTTPServer *http.Server
+	// Services is torn down in reverse-registration order, so a service
+	// that depends on one registered earlier shuts down first.
+	Services  []app.Service
+	Telemetry *telemetry.Telemetry
+
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// DrainTimeout bounds how long each component gets to shut down.
+	// Defaults to DefaultDrainTimeout.
+	DrainTimeout time.Duration
+
+	// ServeErrors receives an error from a server goroutine (e.g.
+	// grpcServer.Serve or httpServer.ListenAndServe) that exited
+	// unexpectedly before a shutdown signal was received, such as a bind
+	// failure. Wait treats this the same as a shutdown signal, except it
+	// reports the error back to the caller instead of nil.
+	ServeErrors <-chan error
+}
+
+// Wait blocks until SIGINT or SIGTERM is received, or a server reports it
+// exited early on ServeErrors, then runs Shutdown with ctx as the base
+// context. It returns the early serve error, if that is what woke it, so
+// the caller can exit non-zero instead of running on with no listeners.
+func (m *Manager) Wait(ctx context.Context) error {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case sig := <-sigCh:
+		m.Logger.Info("received shutdown signal", zap.String("signal", sig.String()))
+		return m.Shutdown(ctx)
+	case err := <-m.ServeErrors:
+		m.Logger.Error("a server exited before shutdown was requested", zap.Error(err))
+		if shutdownErr := m.Shutdown(ctx); shutdownErr != nil {
+			m.Logger.Error("failed to shut down cleanly after a server error", zap.Error(shutdownErr))
+		}
+		return err
+	}
+}
+
+// Shutdown tears every component down in order, bounded by DrainTimeout
+// each. The sequence is recorded as events on a "shutdown" span so
+// operators can see exactly which component delayed termination. It
+// returns the first error encountered, having still attempted every step.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	timeout := m.DrainTimeout
+	if timeout <= 0 {
+		timeout = DefaultDrainTimeout
+	}
+
+	ctx, span := m.Tracer.Start(ctx, "shutdown")
+	defer span.End()
+
+	var firstErr error
+	step := func(name string, fn func(context.Context) error) {
+		stepCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		span.AddEvent(name + ": starting")
+		if err := fn(stepCtx); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			span.AddEvent(name + ": failed")
+			m.Logger.Error("failed to shut down component", zap.String("component", name), zap.Error(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+			return
+		}
+		span.AddEvent(name + ": done")
+	}
+
+	step("grpc_server", m.shutdownGRPCServer)
+
+	step("http_server", m.HTTPServer.Shutdown)
+
+	for i := len(m.Services) - 1; i >= 0; i-- {
+		svc := m.Services[i]
+		step(fmt.Sprintf("service %T", svc), svc.Shutdown)
+	}
+
+	step("telemetry", m.Telemetry.Shutdown)
+
+	return firstErr
+}
+
+// shutdownGRPCServer waits for GracefulStop to drain in-flight RPCs, but
+// falls back to the hard Stop once ctx's deadline passes, so a stuck RPC
+// can't block termination past DrainTimeout.
+func (m *Manager) shutdownGRPCServer(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		m.GRPCServer.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		m.GRPCServer.Stop()
+		<-done
+		return ctx.Err()
+	}
+}
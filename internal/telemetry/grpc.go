@@ -0,0 +1,20 @@
+// Copyright Dose de Telemetria GmbH
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+)
+
+// GRPCServerOptions returns the grpc.ServerOption set every gRPC server in
+// this repository should be built with so that inbound RPCs are traced and
+// measured consistently. Pass the result straight into grpc.NewServer:
+//
+//	grpcServer := grpc.NewServer(telemetry.GRPCServerOptions()...)
+func GRPCServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+	}
+}
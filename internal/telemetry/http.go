@@ -0,0 +1,20 @@
+// Copyright Dose de Telemetria GmbH
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// HTTPHandler wraps handler with otelhttp so every request it serves
+// produces a span named after serviceName and is linked to the exporter
+// configured by Setup. Binaries should wrap their top-level mux with it
+// before handing it to http.Server:
+//
+//	http.ListenAndServe(addr, telemetry.HTTPHandler(mux, "all-in-one"))
+func HTTPHandler(handler http.Handler, serviceName string) http.Handler {
+	return otelhttp.NewHandler(handler, serviceName)
+}
@@ -0,0 +1,130 @@
+// Copyright Dose de Telemetria GmbH
+// SPDX-License-Identifier: Apache-2.0
+
+// Package telemetry builds the OpenTelemetry SDK used by every binary in
+// this repository from a single declarative YAML file.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/contrib/bridges/otelzap"
+	"go.opentelemetry.io/contrib/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ShutdownTimeout bounds how long Shutdown waits for the SDK to flush and
+// close every provider.
+const ShutdownTimeout = 10 * time.Second
+
+// Settings bundles the ready-to-use telemetry primitives a service needs.
+// Passing Settings into an app.NewX constructor keeps services from
+// reaching for otel.Tracer(...) or global.GetLoggerProvider() directly, and
+// lets tests inject their own in-memory provider.
+type Settings struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	Meter  metric.Meter
+}
+
+// Telemetry owns the LoggerProvider, TracerProvider and MeterProvider built
+// from a declarative OTel configuration file.
+type Telemetry struct {
+	// Settings is derived from the providers owned by this Telemetry and is
+	// what services should be constructed with.
+	Settings Settings
+
+	sdk                config.SDK
+	testTracerProvider *sdktrace.TracerProvider
+}
+
+// Setup reads the OTel configuration file at configFile, builds the SDK it
+// describes, registers it as the process-wide default (so instrumentation
+// libraries that only know about global providers, such as otelgrpc, keep
+// working), and returns the resulting Telemetry. serviceName scopes the
+// *zap.Logger bridge and the Settings.Tracer/Settings.Meter instruments.
+// extraProcessors are attached to the TracerProvider in addition to the
+// ones declared in configFile, which is how the zpages debug page gets a
+// live feed of spans.
+func Setup(ctx context.Context, configFile, serviceName string, extraProcessors ...sdktrace.SpanProcessor) (*Telemetry, error) {
+	b, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the otel config file: %w", err)
+	}
+
+	cfg, err := config.ParseYAML(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the otel config file: %w", err)
+	}
+
+	tpOpts := make([]sdktrace.TracerProviderOption, 0, len(extraProcessors))
+	for _, p := range extraProcessors {
+		tpOpts = append(tpOpts, sdktrace.WithSpanProcessor(p))
+	}
+
+	sdk, err := config.NewSDK(
+		config.WithContext(ctx),
+		config.WithOpenTelemetryConfiguration(*cfg),
+		config.WithTracerProviderOptions(tpOpts...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the otel SDK: %w", err)
+	}
+
+	otel.SetTracerProvider(sdk.TracerProvider())
+	otel.SetMeterProvider(sdk.MeterProvider())
+	global.SetLoggerProvider(sdk.LoggerProvider())
+
+	core := zapcore.NewTee(
+		zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(os.Stdout), zapcore.InfoLevel),
+		otelzap.NewCore(serviceName, otelzap.WithLoggerProvider(sdk.LoggerProvider())),
+	)
+
+	return &Telemetry{
+		sdk: sdk,
+		Settings: Settings{
+			Logger: zap.New(core),
+			Tracer: sdk.TracerProvider().Tracer(serviceName),
+			Meter:  sdk.MeterProvider().Meter(serviceName),
+		},
+	}, nil
+}
+
+// Shutdown force-flushes, in order, the tracer, meter and logger providers
+// owned by t, then shuts them all down, bounded by ShutdownTimeout. It
+// returns the first error encountered.
+func (t *Telemetry) Shutdown(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, ShutdownTimeout)
+	defer cancel()
+
+	if t.testTracerProvider != nil {
+		return t.shutdownTest(ctx)
+	}
+
+	type flusher interface{ ForceFlush(context.Context) error }
+	providers := []struct {
+		name     string
+		provider any
+	}{
+		{"tracer", t.sdk.TracerProvider()},
+		{"meter", t.sdk.MeterProvider()},
+		{"logger", t.sdk.LoggerProvider()},
+	}
+	for _, p := range providers {
+		if f, ok := p.provider.(flusher); ok {
+			if err := f.ForceFlush(ctx); err != nil {
+				return fmt.Errorf("failed to flush the %s provider: %w", p.name, err)
+			}
+		}
+	}
+	return t.sdk.Shutdown(ctx)
+}
@@ -0,0 +1,38 @@
+// Copyright Dose de Telemetria GmbH
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/metric/noop"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+)
+
+// NewForTesting builds a Telemetry backed by an in-memory TracerProvider
+// with extraProcessors attached, and no-op logger and meter providers. It
+// lets tests inject their own span processor (an in-memory exporter, for
+// example) instead of configuring a real OTel pipeline from a YAML file.
+func NewForTesting(serviceName string, extraProcessors ...sdktrace.SpanProcessor) *Telemetry {
+	opts := make([]sdktrace.TracerProviderOption, 0, len(extraProcessors))
+	for _, p := range extraProcessors {
+		opts = append(opts, sdktrace.WithSpanProcessor(p))
+	}
+	tp := sdktrace.NewTracerProvider(opts...)
+
+	return &Telemetry{
+		testTracerProvider: tp,
+		Settings: Settings{
+			Logger: zap.NewNop(),
+			Tracer: tp.Tracer(serviceName),
+			Meter:  noop.NewMeterProvider().Meter(serviceName),
+		},
+	}
+}
+
+// shutdownTest shuts down a Telemetry built by NewForTesting.
+func (t *Telemetry) shutdownTest(ctx context.Context) error {
+	return t.testTracerProvider.Shutdown(ctx)
+}
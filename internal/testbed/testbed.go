@@ -0,0 +1,117 @@
+// Copyright Dose de Telemetria GmbH
+// SPDX-License-Identifier: Apache-2.0
+
+// Package testbed boots the all-in-one services inside the current process
+// against ephemeral ports, modeled after the collector's inProcessCollector.
+// It lets end-to-end tests drive real HTTP and gRPC requests and assert on
+// the emitted spans, without spawning subprocesses or scraping stdout.
+package testbed
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/app"
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/config"
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/telemetry"
+	"github.com/dosedetelemetria/projeto-otel-na-pratica/internal/zpages"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Testbed is an in-process instance of the all-in-one server.
+type Testbed struct {
+	httpLis net.Listener
+	grpcLis net.Listener
+	httpSrv *http.Server
+	grpcSrv *grpc.Server
+
+	tel      *telemetry.Telemetry
+	recorder *tracetest.InMemoryExporter
+	running  []app.Service
+}
+
+// New creates an unstarted Testbed.
+func New() *Testbed {
+	return &Testbed{}
+}
+
+// Start boots every service listed in cfg.Services, and their gRPC and
+// HTTP servers, on ephemeral ports. cfg.Server.Endpoint is ignored in favor
+// of the ephemeral ports; use HTTPAddr and GRPCConn to find the addresses
+// that were actually picked.
+func (tb *Testbed) Start(ctx context.Context, cfg *config.Config) error {
+	var err error
+	tb.httpLis, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("failed to listen for http: %w", err)
+	}
+	tb.grpcLis, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("failed to listen for grpc: %w", err)
+	}
+
+	tb.recorder = tracetest.NewInMemoryExporter()
+	tb.tel = telemetry.NewForTesting("testbed", sdktrace.NewSimpleSpanProcessor(tb.recorder))
+	app.RegisterBuiltins(tb.tel.Settings)
+
+	mux := http.NewServeMux()
+	zpages.RegisterRoutes(mux, zpages.NewProcessor(), zpages.NewRegistry())
+
+	tb.grpcSrv = grpc.NewServer(telemetry.GRPCServerOptions()...)
+
+	for _, entry := range cfg.Services {
+		svc, err := app.New(entry.Name, entry.Settings)
+		if err != nil {
+			return fmt.Errorf("failed to create service %q: %w", entry.Name, err)
+		}
+		if err := svc.RegisterRoutes(mux, tb.grpcSrv); err != nil {
+			return fmt.Errorf("failed to register routes for service %q: %w", entry.Name, err)
+		}
+		tb.running = append(tb.running, svc)
+	}
+
+	tb.httpSrv = &http.Server{Handler: telemetry.HTTPHandler(mux, "testbed")}
+
+	go tb.grpcSrv.Serve(tb.grpcLis)
+	go tb.httpSrv.Serve(tb.httpLis)
+
+	return nil
+}
+
+// HTTPAddr returns the address the in-process HTTP server is listening on.
+func (tb *Testbed) HTTPAddr() string {
+	return tb.httpLis.Addr().String()
+}
+
+// GRPCConn dials the in-process gRPC server.
+func (tb *Testbed) GRPCConn() (*grpc.ClientConn, error) {
+	return grpc.NewClient(tb.grpcLis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+// SpanRecorder returns the in-memory exporter that records the spans the
+// services start through their telemetry.Settings tracer. The otelhttp and
+// otelgrpc server spans use the global TracerProvider and are not recorded
+// here.
+func (tb *Testbed) SpanRecorder() *tracetest.InMemoryExporter {
+	return tb.recorder
+}
+
+// Stop tears down the gRPC and HTTP servers, every running service in
+// reverse-registration order, and flushes telemetry.
+func (tb *Testbed) Stop(ctx context.Context) error {
+	tb.grpcSrv.GracefulStop()
+	if err := tb.httpSrv.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shut down the http server: %w", err)
+	}
+	for i := len(tb.running) - 1; i >= 0; i-- {
+		if err := tb.running[i].Shutdown(ctx); err != nil {
+			return fmt.Errorf("failed to shut down a service: %w", err)
+		}
+	}
+	return tb.tel.Shutdown(ctx)
+}
@@ -0,0 +1,57 @@
+// Copyright Dose de Telemetria GmbH
+// SPDX-License-Identifier: Apache-2.0
+
+package zpages
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+)
+
+var tracezTemplate = template.Must(template.New("tracez").Parse(`<html><head><title>tracez</title></head><body>
+<h1>Spans</h1>
+<table border="1" cellpadding="4">
+<tr><th>Name</th><th>Active</th><th>Latency buckets</th><th>Error samples</th></tr>
+{{range .}}
+<tr>
+<td>{{.Name}}</td>
+<td>{{.Active}}</td>
+<td>{{range .Latencies}}{{.UpperBound}}: {{.Count}}<br>{{end}}</td>
+<td>{{range .Errors}}{{.When.Format "15:04:05.000"}} {{.Message}}<br>{{end}}</td>
+</tr>
+{{end}}
+</table>
+</body></html>`))
+
+// servicezTemplate lists the services running in the process and the HTTP
+// routes each one registered. It has no health column: the Registry tracks
+// which services started, not whether they're still healthy, and a
+// hardcoded "OK" would claim a signal this page doesn't measure.
+var servicezTemplate = template.Must(template.New("servicez").Parse(`<html><head><title>servicez</title></head><body>
+<h1>Services</h1>
+<table border="1" cellpadding="4">
+<tr><th>Name</th><th>Routes</th></tr>
+{{range .}}
+<tr><td>{{.Name}}</td><td>{{range .Routes}}{{.}}<br>{{end}}</td></tr>
+{{end}}
+</table>
+</body></html>`))
+
+// RegisterRoutes mounts the /debug/tracez and /debug/servicez handlers on
+// mux, rendering live data from p and reg.
+func RegisterRoutes(mux *http.ServeMux, p *Processor, reg *Registry) {
+	mux.HandleFunc("/debug/tracez", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := tracezTemplate.Execute(w, p.summaries()); err != nil {
+			http.Error(w, fmt.Sprintf("failed to render tracez: %v", err), http.StatusInternalServerError)
+		}
+	})
+
+	mux.HandleFunc("/debug/servicez", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := servicezTemplate.Execute(w, reg.Services()); err != nil {
+			http.Error(w, fmt.Sprintf("failed to render servicez: %v", err), http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,217 @@
+// Copyright Dose de Telemetria GmbH
+// SPDX-License-Identifier: Apache-2.0
+
+// Package zpages implements a small in-process debug surface, analogous to
+// the OTel Collector's zpagesextension, that renders live span data without
+// needing to ship traces out to a backend first.
+package zpages
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// defaultBucketSize is how many completed spans are kept per span name.
+const defaultBucketSize = 16
+
+// latencyBucketBounds are the upper bounds of the latency histogram
+// rendered by /debug/tracez, e.g. the first bucket counts spans that took
+// 1ms or less.
+var latencyBucketBounds = []time.Duration{
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+}
+
+// latencyBucket is one bar of the per-span-name latency histogram rendered
+// by /debug/tracez.
+type latencyBucket struct {
+	UpperBound string
+	Count      int
+}
+
+// errorSample is a single errored span, rendered in the error samples
+// column of /debug/tracez.
+type errorSample struct {
+	When    time.Time
+	Message string
+}
+
+// completedSpan is the subset of a finished span's data rendered by
+// /debug/tracez.
+type completedSpan struct {
+	name      string
+	start     time.Time
+	latency   time.Duration
+	isError   bool
+	statusMsg string
+}
+
+// bucket holds the most recently completed spans for a single span name.
+type bucket struct {
+	spans []completedSpan
+	next  int
+	count int
+}
+
+func (b *bucket) add(s completedSpan) {
+	if len(b.spans) == 0 {
+		b.spans = make([]completedSpan, defaultBucketSize)
+	}
+	b.spans[b.next] = s
+	b.next = (b.next + 1) % len(b.spans)
+	if b.count < len(b.spans) {
+		b.count++
+	}
+}
+
+func (b *bucket) recent() []completedSpan {
+	out := make([]completedSpan, 0, b.count)
+	for i := 0; i < b.count; i++ {
+		idx := (b.next - b.count + i + len(b.spans)) % len(b.spans)
+		out = append(out, b.spans[idx])
+	}
+	return out
+}
+
+// Processor is an sdktrace.SpanProcessor that keeps a bounded, in-memory
+// view of recently-completed and currently-active spans, keyed by span
+// name, for the zpages HTTP handlers to render.
+type Processor struct {
+	mu       sync.Mutex
+	active   map[string]map[string]completedSpan // span name -> span ID -> started span
+	finished map[string]*bucket                   // span name -> ring buffer
+}
+
+// NewProcessor creates a Processor ready to be registered with an
+// sdktrace.TracerProvider via sdktrace.WithSpanProcessor.
+func NewProcessor() *Processor {
+	return &Processor{
+		active:   make(map[string]map[string]completedSpan),
+		finished: make(map[string]*bucket),
+	}
+}
+
+// OnStart records s as currently active.
+func (p *Processor) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	name := s.Name()
+	if p.active[name] == nil {
+		p.active[name] = make(map[string]completedSpan)
+	}
+	p.active[name][s.SpanContext().SpanID().String()] = completedSpan{
+		name:  name,
+		start: s.StartTime(),
+	}
+}
+
+// OnEnd moves s from the active set into the completed ring buffer for its
+// span name.
+func (p *Processor) OnEnd(s sdktrace.ReadOnlySpan) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	name := s.Name()
+	delete(p.active[name], s.SpanContext().SpanID().String())
+
+	cs := completedSpan{
+		name:      name,
+		start:     s.StartTime(),
+		latency:   s.EndTime().Sub(s.StartTime()),
+		isError:   s.Status().Code == codes.Error,
+		statusMsg: s.Status().Description,
+	}
+
+	b := p.finished[name]
+	if b == nil {
+		b = &bucket{}
+		p.finished[name] = b
+	}
+	b.add(cs)
+}
+
+// Shutdown implements sdktrace.SpanProcessor. The Processor holds no
+// external resources, so there is nothing to release.
+func (p *Processor) Shutdown(context.Context) error { return nil }
+
+// ForceFlush implements sdktrace.SpanProcessor. The Processor buffers
+// in-memory only, so there is nothing to flush.
+func (p *Processor) ForceFlush(context.Context) error { return nil }
+
+// spanNameSummary is a snapshot of the active and recently-completed spans
+// for a single span name, used to render /debug/tracez.
+type spanNameSummary struct {
+	Name      string
+	Active    int
+	Latencies []latencyBucket
+	Errors    []errorSample
+}
+
+// summaries returns a snapshot of every span name the Processor has seen,
+// sorted by name.
+func (p *Processor) summaries() []spanNameSummary {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	names := make(map[string]struct{})
+	for name := range p.active {
+		names[name] = struct{}{}
+	}
+	for name := range p.finished {
+		names[name] = struct{}{}
+	}
+
+	out := make([]spanNameSummary, 0, len(names))
+	for name := range names {
+		s := spanNameSummary{Name: name, Active: len(p.active[name])}
+		if b := p.finished[name]; b != nil {
+			recent := b.recent()
+			s.Latencies = latencyHistogram(recent)
+			s.Errors = errorSamples(recent)
+		}
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// latencyHistogram buckets spans by latency against latencyBucketBounds,
+// with a final overflow bucket for anything slower than the last bound.
+func latencyHistogram(spans []completedSpan) []latencyBucket {
+	counts := make([]int, len(latencyBucketBounds)+1)
+	for _, s := range spans {
+		i := sort.Search(len(latencyBucketBounds), func(i int) bool {
+			return s.latency <= latencyBucketBounds[i]
+		})
+		counts[i]++
+	}
+
+	out := make([]latencyBucket, len(counts))
+	for i, count := range counts {
+		label := ">" + latencyBucketBounds[len(latencyBucketBounds)-1].String()
+		if i < len(latencyBucketBounds) {
+			label = "<=" + latencyBucketBounds[i].String()
+		}
+		out[i] = latencyBucket{UpperBound: label, Count: count}
+	}
+	return out
+}
+
+// errorSamples returns the errored spans in spans, in their original order.
+func errorSamples(spans []completedSpan) []errorSample {
+	var out []errorSample
+	for _, s := range spans {
+		if s.isError {
+			out = append(out, errorSample{When: s.start, Message: s.statusMsg})
+		}
+	}
+	return out
+}
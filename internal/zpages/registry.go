@@ -0,0 +1,40 @@
+// Copyright Dose de Telemetria GmbH
+// SPDX-License-Identifier: Apache-2.0
+
+package zpages
+
+import "sync"
+
+// ServiceInfo describes a registered service for /debug/servicez.
+type ServiceInfo struct {
+	Name   string
+	Routes []string
+}
+
+// Registry tracks the services running in the current process so
+// /debug/servicez can list them alongside their route tables.
+type Registry struct {
+	mu       sync.Mutex
+	services []ServiceInfo
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// RegisterService records name as running with the given routes.
+func (r *Registry) RegisterService(name string, routes []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.services = append(r.services, ServiceInfo{Name: name, Routes: routes})
+}
+
+// Services returns a snapshot of every registered service.
+func (r *Registry) Services() []ServiceInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]ServiceInfo, len(r.services))
+	copy(out, r.services)
+	return out
+}